@@ -0,0 +1,154 @@
+package acl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/template"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/posener/complete"
+)
+
+// GetBindingRuleIDFromPartial returns the full ID of a binding rule from the
+// given ID or ID prefix. An error is returned if the prefix is ambiguous or
+// does not match any binding rule.
+func GetBindingRuleIDFromPartial(client *api.Client, partialID string) (string, error) {
+	rules, _, err := client.ACL().BindingRuleList("", nil)
+	if err != nil {
+		return "", fmt.Errorf("error looking up binding rules: %v", err)
+	}
+
+	var matches []string
+	for _, rule := range rules {
+		if rule.ID == partialID {
+			return rule.ID, nil
+		}
+		if len(partialID) <= len(rule.ID) && rule.ID[0:len(partialID)] == partialID {
+			matches = append(matches, rule.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no such binding rule ID with prefix %q", partialID)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("binding rule ID prefix %q is ambiguous (%d matches)", partialID, len(matches))
+	}
+}
+
+// FormatBindingRule renders a binding rule according to format. The "pretty"
+// format matches the traditional human-readable UI output, while "json"
+// marshals the *api.ACLBindingRule struct, including Hash/CreateIndex/
+// ModifyIndex only when showMeta is set. When tmpl is non-empty it takes
+// precedence over format and is evaluated as a text/template against the
+// full rule.
+func FormatBindingRule(rule *api.ACLBindingRule, showMeta bool, format, tmpl string) (string, error) {
+	if tmpl != "" {
+		t, err := template.New("rule").Parse(tmpl)
+		if err != nil {
+			return "", fmt.Errorf("error parsing template: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, rule); err != nil {
+			return "", fmt.Errorf("error executing template: %v", err)
+		}
+		return buf.String(), nil
+	}
+
+	switch format {
+	case "", "pretty":
+		return formatBindingRulePretty(rule, showMeta), nil
+	case "json":
+		b, err := json.MarshalIndent(bindingRuleJSON(rule, showMeta), "", "    ")
+		if err != nil {
+			return "", fmt.Errorf("error marshaling binding rule: %v", err)
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+// bindingRuleJSON returns the value to marshal for -format json. It omits
+// Hash/CreateIndex/ModifyIndex unless showMeta is set, mirroring
+// formatBindingRulePretty's conditional inclusion of those fields.
+func bindingRuleJSON(rule *api.ACLBindingRule, showMeta bool) interface{} {
+	if showMeta {
+		return rule
+	}
+
+	return &api.ACLBindingRule{
+		ID:           rule.ID,
+		IDPName:      rule.IDPName,
+		Description:  rule.Description,
+		Selector:     rule.Selector,
+		RoleBindType: rule.RoleBindType,
+		RoleName:     rule.RoleName,
+	}
+}
+
+func formatBindingRulePretty(rule *api.ACLBindingRule, showMeta bool) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "ID:           %s\n", rule.ID)
+	fmt.Fprintf(&buf, "IDPName:      %s\n", rule.IDPName)
+	fmt.Fprintf(&buf, "Description:  %s\n", rule.Description)
+	fmt.Fprintf(&buf, "RoleBindType: %s\n", rule.RoleBindType)
+	fmt.Fprintf(&buf, "RoleName:     %s\n", rule.RoleName)
+	fmt.Fprintf(&buf, "Selector:     %s\n", rule.Selector)
+	if showMeta {
+		fmt.Fprintf(&buf, "Hash:         %x\n", rule.Hash)
+		fmt.Fprintf(&buf, "Create Index: %d\n", rule.CreateIndex)
+		fmt.Fprintf(&buf, "Modify Index: %d\n", rule.ModifyIndex)
+	}
+	return buf.String()
+}
+
+// LoadBindingRuleFromPayload reads a JSON document matching *api.ACLBindingRule
+// from the file at path, or from stdin when path is "-".
+func LoadBindingRuleFromPayload(path string) (*api.ACLBindingRule, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading payload: %v", err)
+	}
+
+	var rule api.ACLBindingRule
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return nil, fmt.Errorf("error parsing payload as JSON: %v", err)
+	}
+	return &rule, nil
+}
+
+// BindingRuleIDPredictor completes against the IDs of existing binding rules
+// known to the Consul agent reached by client().
+func BindingRuleIDPredictor(client func() *api.Client) complete.Predictor {
+	return complete.PredictFunc(func(args complete.Args) []string {
+		c := client()
+		if c == nil {
+			return nil
+		}
+
+		rules, _, err := c.ACL().BindingRuleList("", nil)
+		if err != nil {
+			return nil
+		}
+
+		ids := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			ids = append(ids, rule.ID)
+		}
+		return ids
+	})
+}
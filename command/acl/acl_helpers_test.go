@@ -0,0 +1,64 @@
+package acl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+)
+
+func testBindingRule() *api.ACLBindingRule {
+	return &api.ACLBindingRule{
+		ID:           "acd825db-3fde-4d33-a9d5-5a0e45e54fd0",
+		IDPName:      "k8s",
+		Description:  "test rule",
+		RoleBindType: api.BindingRuleRoleBindTypeService,
+		RoleName:     "k8s-{{serviceaccount.name}}",
+		Selector:     "serviceaccount.namespace==default",
+		Hash:         []byte{0x01, 0x02},
+		CreateIndex:  5,
+		ModifyIndex:  10,
+	}
+}
+
+func TestFormatBindingRule_JSON_NoMeta(t *testing.T) {
+	out, err := FormatBindingRule(testBindingRule(), false, "json", "")
+	require.NoError(t, err)
+
+	var rule api.ACLBindingRule
+	require.NoError(t, json.Unmarshal([]byte(out), &rule))
+	require.Zero(t, rule.CreateIndex)
+	require.Zero(t, rule.ModifyIndex)
+	require.Empty(t, rule.Hash)
+	require.Equal(t, "test rule", rule.Description)
+}
+
+func TestFormatBindingRule_JSON_WithMeta(t *testing.T) {
+	out, err := FormatBindingRule(testBindingRule(), true, "json", "")
+	require.NoError(t, err)
+
+	var rule api.ACLBindingRule
+	require.NoError(t, json.Unmarshal([]byte(out), &rule))
+	require.EqualValues(t, 5, rule.CreateIndex)
+	require.EqualValues(t, 10, rule.ModifyIndex)
+	require.NotEmpty(t, rule.Hash)
+}
+
+func TestFormatBindingRule_Pretty(t *testing.T) {
+	out, err := FormatBindingRule(testBindingRule(), false, "pretty", "")
+	require.NoError(t, err)
+	require.Contains(t, out, "Description:  test rule")
+	require.NotContains(t, out, "Hash:")
+}
+
+func TestFormatBindingRule_Template(t *testing.T) {
+	out, err := FormatBindingRule(testBindingRule(), false, "", "{{ .RoleName }}")
+	require.NoError(t, err)
+	require.Equal(t, "k8s-{{serviceaccount.name}}", out)
+}
+
+func TestFormatBindingRule_UnknownFormat(t *testing.T) {
+	_, err := FormatBindingRule(testBindingRule(), false, "yaml", "")
+	require.Error(t, err)
+}
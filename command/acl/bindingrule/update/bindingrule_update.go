@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/consul/command/acl"
 	"github.com/hashicorp/consul/command/flags"
 	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
 )
 
 func New(ui cli.Ui) *cmd {
@@ -31,6 +32,10 @@ type cmd struct {
 
 	noMerge  bool
 	showMeta bool
+
+	format  string
+	tmpl    string
+	payload string
 }
 
 func (c *cmd) init() {
@@ -88,6 +93,33 @@ func (c *cmd) init() {
 			"with the exception of the binding rule ID which is immutable.",
 	)
 
+	c.flags.StringVar(
+		&c.payload,
+		"payload",
+		"",
+		"A file path or '-' (for stdin) pointing to a JSON document matching "+
+			"the api.ACLBindingRule structure to use as the update body. When "+
+			"-no-merge is set the payload is authoritative; otherwise its "+
+			"non-zero fields are merged onto the current rule. Individual "+
+			"-description/-selector/-role-name/-role-bind-type flags, if also "+
+			"present, override values from the payload.",
+	)
+
+	c.flags.StringVar(
+		&c.format,
+		"format",
+		"pretty",
+		"Output format {pretty|json}",
+	)
+	c.flags.StringVar(
+		&c.tmpl,
+		"t",
+		"",
+		"Go text/template string to render the output with. Takes "+
+			"precedence over -format and is evaluated against the returned "+
+			"*api.ACLBindingRule.",
+	)
+
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
 	flags.Merge(c.flags, c.http.ServerFlags())
@@ -126,31 +158,67 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	var payloadRule *api.ACLBindingRule
+	if c.payload != "" {
+		payloadRule, err = acl.LoadBindingRuleFromPayload(c.payload)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error loading payload: %v", err))
+			return 1
+		}
+	}
+
 	var rule *api.ACLBindingRule
 	if c.noMerge {
-		if c.roleName == "" {
+		if payloadRule != nil {
+			rule = payloadRule
+		} else {
+			rule = &api.ACLBindingRule{}
+		}
+		rule.ID = ruleID
+		rule.IDPName = currentRule.IDPName // immutable
+
+		if isFlagSet(c.flags, "description") {
+			rule.Description = c.description // empty is valid
+		}
+		if isFlagSet(c.flags, "role-name") {
+			rule.RoleName = c.roleName // empty is valid
+		}
+		if isFlagSet(c.flags, "role-bind-type") || rule.RoleBindType == "" {
+			rule.RoleBindType = api.BindingRuleRoleBindType(c.roleBindType)
+		}
+		if isFlagSet(c.flags, "selector") {
+			rule.Selector = c.selector // empty is valid
+		}
+
+		if rule.RoleName == "" {
 			c.UI.Error(fmt.Sprintf("Missing required '-role-name' flag"))
 			c.UI.Error(c.Help())
 			return 1
 		}
 
-		rule = &api.ACLBindingRule{
-			ID:           ruleID,
-			IDPName:      currentRule.IDPName, // immutable
-			Description:  c.description,
-			RoleBindType: api.BindingRuleRoleBindType(c.roleBindType),
-			RoleName:     c.roleName,
-			Selector:     c.selector,
-		}
-
 	} else {
 		rule = currentRule
 
-		if c.description != "" {
-			rule.Description = c.description
+		if payloadRule != nil {
+			if payloadRule.Description != "" {
+				rule.Description = payloadRule.Description
+			}
+			if payloadRule.RoleName != "" {
+				rule.RoleName = payloadRule.RoleName
+			}
+			if payloadRule.RoleBindType != "" {
+				rule.RoleBindType = payloadRule.RoleBindType
+			}
+			if payloadRule.Selector != "" {
+				rule.Selector = payloadRule.Selector
+			}
+		}
+
+		if isFlagSet(c.flags, "description") {
+			rule.Description = c.description // empty is valid
 		}
-		if c.roleName != "" {
-			rule.RoleName = c.roleName
+		if isFlagSet(c.flags, "role-name") {
+			rule.RoleName = c.roleName // empty is valid
 		}
 		if isFlagSet(c.flags, "role-bind-type") {
 			rule.RoleBindType = api.BindingRuleRoleBindType(c.roleBindType) // empty is valid
@@ -166,8 +234,16 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
-	c.UI.Info(fmt.Sprintf("Binding rule updated successfully"))
-	acl.PrintBindingRule(rule, c.UI, c.showMeta)
+	output, err := acl.FormatBindingRule(rule, c.showMeta, c.format, c.tmpl)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error formatting binding rule: %v", err))
+		return 1
+	}
+
+	if c.tmpl == "" && c.format != "json" {
+		c.UI.Info(fmt.Sprintf("Binding rule updated successfully"))
+	}
+	c.UI.Info(output)
 	return 0
 }
 
@@ -179,6 +255,22 @@ func (c *cmd) Help() string {
 	return flags.Usage(c.help, nil)
 }
 
+func (c *cmd) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-id": acl.BindingRuleIDPredictor(acl.AutocompleteClient(c.http)),
+		"-role-bind-type": complete.PredictSet(
+			string(api.BindingRuleRoleBindTypeService),
+			string(api.BindingRuleRoleBindTypeExisting),
+		),
+		"-format":  complete.PredictSet("pretty", "json"),
+		"-payload": complete.PredictOr(complete.PredictFiles("*"), complete.PredictSet("-")),
+	}
+}
+
+func (c *cmd) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func isFlagSet(flags *flag.FlagSet, name string) bool {
 	found := false
 	flags.Visit(func(f *flag.Flag) {
@@ -205,4 +297,9 @@ Usage: consul acl binding-rule update -id ID [options]
             -role-bind-type=existing \
             -role-name="k8s-{{serviceaccount.name}}" \
             -selector='serviceaccount.namespace==default and serviceaccount.name==web'
+
+    Update a binding rule from a JSON payload file:
+
+     $ consul acl binding-rule update -id=43cb72df-9c6f-4315-ac8a-01a9d98155ef \
+            -payload=rule.json
 `
@@ -0,0 +1,212 @@
+package bindingruleupdate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingRuleUpdateCommand_noTabs(t *testing.T) {
+	t.Parallel()
+
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestBindingRuleUpdateCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := agent.NewTestAgent(t, t.Name(), ``)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	client := a.Client()
+
+	createRule := func(t *testing.T) *api.ACLBindingRule {
+		t.Helper()
+
+		idp, _, err := client.ACL().IdentityProviderCreate(&api.ACLIdentityProvider{
+			Name: "k8s",
+			Type: "kubernetes",
+		}, nil)
+		require.NoError(t, err)
+
+		rule, _, err := client.ACL().BindingRuleCreate(&api.ACLBindingRule{
+			IDPName:      idp.Name,
+			Description:  "original description",
+			Selector:     "serviceaccount.namespace==default",
+			RoleBindType: api.BindingRuleRoleBindTypeService,
+			RoleName:     "k8s-{{serviceaccount.name}}",
+		}, nil)
+		require.NoError(t, err)
+		return rule
+	}
+
+	writePayload := func(t *testing.T, v interface{}) string {
+		t.Helper()
+
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		f, err := ioutil.TempFile("", "bindingrule-payload")
+		require.NoError(t, err)
+		defer f.Close()
+
+		_, err = f.Write(b)
+		require.NoError(t, err)
+		return f.Name()
+	}
+
+	t.Run("no-merge with payload is authoritative", func(t *testing.T) {
+		rule := createRule(t)
+		payloadPath := writePayload(t, &api.ACLBindingRule{
+			Description:  "from payload",
+			RoleBindType: api.BindingRuleRoleBindTypeService,
+			RoleName:     "payload-{{serviceaccount.name}}",
+			Selector:     "serviceaccount.namespace==payload",
+		})
+		defer os.Remove(payloadPath)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-no-merge",
+			"-payload=" + payloadPath,
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		updated, _, err := client.ACL().BindingRuleRead(rule.ID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "from payload", updated.Description)
+		require.Equal(t, "payload-{{serviceaccount.name}}", updated.RoleName)
+		require.Equal(t, "serviceaccount.namespace==payload", updated.Selector)
+		require.Equal(t, rule.IDPName, updated.IDPName) // immutable
+	})
+
+	t.Run("merge with payload and an overriding flag", func(t *testing.T) {
+		rule := createRule(t)
+		payloadPath := writePayload(t, &api.ACLBindingRule{
+			Description: "from payload",
+			Selector:    "serviceaccount.namespace==payload",
+		})
+		defer os.Remove(payloadPath)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-payload=" + payloadPath,
+			"-selector=serviceaccount.namespace==override",
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		updated, _, err := client.ACL().BindingRuleRead(rule.ID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "from payload", updated.Description)
+		require.Equal(t, "serviceaccount.namespace==override", updated.Selector) // flag wins
+		require.Equal(t, rule.RoleName, updated.RoleName)                       // untouched by payload or flags
+	})
+
+	t.Run("format json omits meta fields without -meta", func(t *testing.T) {
+		rule := createRule(t)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-description=updated",
+			"-format=json",
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var out api.ACLBindingRule
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+		require.Zero(t, out.CreateIndex)
+		require.Zero(t, out.ModifyIndex)
+		require.Empty(t, out.Hash)
+	})
+
+	t.Run("format json includes meta fields with -meta", func(t *testing.T) {
+		rule := createRule(t)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-description=updated",
+			"-format=json",
+			"-meta",
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var out api.ACLBindingRule
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+		require.NotZero(t, out.ModifyIndex)
+		require.NotEmpty(t, out.Hash)
+	})
+
+	t.Run("template rendering", func(t *testing.T) {
+		rule := createRule(t)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-description=templated",
+			"-t={{ .Description }}",
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.OutputWriter.String(), "templated")
+	})
+
+	t.Run("template parse error", func(t *testing.T) {
+		rule := createRule(t)
+
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=root",
+			"-id=" + rule.ID,
+			"-t={{ .Description",
+		}
+		code := cmd.Run(args)
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "error parsing template")
+	})
+}
@@ -0,0 +1,21 @@
+package acl
+
+import (
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+// AutocompleteClient lazily constructs a Consul API client from the given
+// HTTPFlags each time it is invoked; the client is not cached. Predictor callbacks run before a
+// command's flags are necessarily fully parsed, so the client must be built
+// on demand rather than up front, and errors are swallowed since predictors
+// have no way to surface them to the user.
+func AutocompleteClient(httpFlags *flags.HTTPFlags) func() *api.Client {
+	return func() *api.Client {
+		client, err := httpFlags.APIClient()
+		if err != nil {
+			return nil
+		}
+		return client
+	}
+}